@@ -76,13 +76,15 @@ func JulianFromFloat(j float64) JulianDate {
 }
 
 // DayTime returns the julianDayNumber and the nanoseconds since the
-//  beginning of the day
+//
+//	beginning of the day
 func (j JulianDate) DayTime() (julianDayNumber, time int64) {
 	return j.julianDayNumber, j.time
 }
 
 // JulianFromDayTime creates a JulianDate from a julianDaynumber and the
-//  nanoseconds since the beginning of the day
+//
+//	nanoseconds since the beginning of the day
 func JulianFromDayTime(julianDayNumber, time int64) JulianDate {
 	jdn := JulianDate{
 		julianDayNumber: julianDayNumber,
@@ -101,10 +103,21 @@ const (
 )
 
 func rightAscension(l, b float64) float64 {
-	return math.Atan2(math.Sin(l)*math.Cos(e)-math.Tan(b)*math.Sin(e), math.Cos(l))
+	return rightAscensionEps(l, b, e)
 }
 func declination(l, b float64) float64 {
-	return math.Asin(math.Sin(b)*math.Cos(e) + math.Cos(b)*math.Sin(e)*math.Sin(l))
+	return declinationEps(l, b, e)
+}
+
+// rightAscensionEps and declinationEps are rightAscension/declination
+// generalized to an arbitrary obliquity of the ecliptic, so high-precision
+// callers can pass the true (nutated) obliquity instead of the fixed
+// constant e.
+func rightAscensionEps(l, b, eps float64) float64 {
+	return math.Atan2(math.Sin(l)*math.Cos(eps)-math.Tan(b)*math.Sin(eps), math.Cos(l))
+}
+func declinationEps(l, b, eps float64) float64 {
+	return math.Asin(math.Sin(b)*math.Cos(eps) + math.Cos(b)*math.Sin(eps)*math.Sin(l))
 }
 
 func azimuth(H, phi, dec float64) float64 {
@@ -162,7 +175,7 @@ type sunTime struct {
 	setName  string
 }
 
-//A SunCalc represents a object to calculate sun times from earth
+// A SunCalc represents a object to calculate sun times from earth
 type SunCalc struct {
 	times []sunTime
 }
@@ -182,14 +195,25 @@ func NewSunCalc() SunCalc {
 	}
 }
 
-// GetPosition calculates sun position for a given date and latitude/longitude
-func (s *SunCalc) GetPosition(date time.Time, lat, lng float64) (azim, alti float64) {
+// deltaUT1Of returns the user-supplied ΔUT1 = UT1 − UTC correction (in
+// seconds), or 0 when none is given.
+func deltaUT1Of(deltaUT1 []float64) float64 {
+	if len(deltaUT1) > 0 {
+		return deltaUT1[0]
+	}
+	return 0
+}
+
+// GetPosition calculates sun position for a given date and latitude/longitude.
+// An optional ΔUT1 = UT1 − UTC correction (in seconds) can be supplied when
+// sub-second sidereal-time accuracy is required; it defaults to 0 otherwise.
+func (s *SunCalc) GetPosition(date time.Time, lat, lng float64, deltaUT1 ...float64) (azim, alti float64) {
 	lw := rad * -lng
 	phi := rad * lat
 	d := toDays(date)
 
-	dec, ra := sunCoords(d)
-	h := siderealTime(d, lw) - ra
+	dec, ra := sunCoords(d.AddSeconds(deltaTSeconds(date)))
+	h := siderealTime(d.AddSeconds(deltaUT1Of(deltaUT1)), lw) - ra
 
 	azim = azimuth(h, phi, dec)
 	alti = altitude(h, phi, dec)
@@ -251,7 +275,11 @@ func (s *SunCalc) GetTimes(date time.Time, lat, lng float64) map[string]time.Tim
 	n := julianCycle(d, lw)
 	ds := approxTransit(0, lw, n)
 
-	m := solarMeanAnomaly(JulianFromFloat(ds))
+	// m/l/dec are the sun's position, so - as in GetPosition - they're
+	// evaluated at TT rather than ds's raw UT estimate; jNoon/jSet/jRise
+	// below stay on the UT timescale, matching the quae.nl rise/set
+	// algorithm's day-offset arithmetic.
+	m := solarMeanAnomaly(JulianFromFloat(ds).AddSeconds(deltaTSeconds(date)))
 	l := eclipticLongitude(m)
 	dec := declination(l, 0)
 
@@ -294,17 +322,21 @@ func moonCoords(jd JulianDate) (dec, ra, dist float64) { // geocentric ecliptic
 }
 
 // GetMoonPosition returns the following properties:
-//  alti: moon altitude above the horizon in radians
-//  azim: moon azimuth in radians
-//  dist: distance to moon in kilometers
-func GetMoonPosition(date time.Time, lat, lng float64) (azim, alti, dist float64) {
+//
+//	alti: moon altitude above the horizon in radians
+//	azim: moon azimuth in radians
+//	dist: distance to moon in kilometers
+//
+// An optional ΔUT1 = UT1 − UTC correction (in seconds) can be supplied when
+// sub-second sidereal-time accuracy is required; it defaults to 0 otherwise.
+func GetMoonPosition(date time.Time, lat, lng float64, deltaUT1 ...float64) (azim, alti, dist float64) {
 
 	lw := rad * -lng
 	phi := rad * lat
 	d := toDays(date)
 
-	dec, ra, distance := moonCoords(d)
-	H := siderealTime(d, lw) - ra
+	dec, ra, distance := moonCoords(d.AddSeconds(deltaTSeconds(date)))
+	H := siderealTime(d.AddSeconds(deltaUT1Of(deltaUT1)), lw) - ra
 	h := altitude(H, phi, dec)
 
 	// altitude correction for refraction
@@ -322,12 +354,14 @@ const sdist = 149598000 // distance from Earth to Sun in km
 // Chapter 48 of "Astronomical Algorithms" 2nd edition by Jean Meeus (Willmann-Bell, Richmond) 1998.
 
 // GetMoonIllumination returns an the following properties:
-//  fraction: illuminated fraction of the moon; varies from `0.0` (new moon) to `1.0` (full moon)
-//  phase: moon phase; varies from `0.0` to `1.0`, described below
-//  angle: midpoint angle in radians of the illuminated limb of the moon reckoned eastward from the north point of the disk;
+//
+//	fraction: illuminated fraction of the moon; varies from `0.0` (new moon) to `1.0` (full moon)
+//	phase: moon phase; varies from `0.0` to `1.0`, described below
+//	angle: midpoint angle in radians of the illuminated limb of the moon reckoned eastward from the north point of the disk;
+//
 // the moon is waxing if the angle is negative, and waning if positive
 func GetMoonIllumination(date time.Time) (fraction, phase, angle float64) {
-	d := toDays(date)
+	d := toDays(date).AddSeconds(deltaTSeconds(date))
 	sDec, sRa := sunCoords(d)
 	mDec, mRa, mDist := moonCoords(d)
 
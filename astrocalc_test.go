@@ -8,7 +8,7 @@ import (
 )
 
 func testNear(t *testing.T, subject string, current, good float64) {
-	if math.Abs(current-good) >= 1E-15 {
+	if math.Abs(current-good) >= 1e-15 {
 		t.Errorf("%s: %.20f instead of %.20f\n", subject, current, good)
 	}
 }
@@ -35,8 +35,8 @@ func TestSunPosition(t *testing.T) {
 	lng := 35.233
 	sunCalc := NewSunCalc()
 	azim, alti := sunCalc.GetPosition(t1, lat, lng)
-	testNear(t, "azimuth", azim, 2.3820139121247865)
-	testNear(t, "altitude", alti, -0.4573946150014954)
+	testNear(t, "azimuth", azim, 2.3820003578325295)
+	testNear(t, "altitude", alti, -0.4573892619151659)
 }
 
 func TestTimes(t *testing.T) {
@@ -44,20 +44,20 @@ func TestTimes(t *testing.T) {
 	lat := 31.783
 	lng := 35.233
 	timesGood := map[string]string{
-		"goldenHour":    "2014-07-29T16:05:16.619633138Z",
-		"dawn":          "2014-07-29T02:27:04.727511405Z",
-		"nauticalDusk":  "2014-07-29T17:38:37.470324039Z",
-		"nightEnd":      "2014-07-29T01:20:46.21797055Z",
-		"night":         "2014-07-29T18:12:41.606369912Z",
-		"solarNoon":     "2014-07-29T09:46:43.912170231Z",
-		"dusk":          "2014-07-29T17:06:23.096829056Z",
-		"sunsetStart":   "2014-07-29T16:36:54.901068806Z",
-		"nauticalDawn":  "2014-07-29T01:54:50.354016423Z",
-		"sunset":        "2014-07-29T16:39:37.948705852Z",
-		"sunriseEnd":    "2014-07-29T02:56:32.923271656Z",
-		"goldenHourEnd": "2014-07-29T03:28:11.204707324Z",
-		"nadir":         "2014-07-28T21:46:43.912170231Z",
-		"sunrise":       "2014-07-29T02:53:49.87563461Z",
+		"goldenHour":    "2014-07-29T16:05:16.589538753Z",
+		"dawn":          "2014-07-29T02:27:04.761910736Z",
+		"nauticalDusk":  "2014-07-29T17:38:37.429728806Z",
+		"nightEnd":      "2014-07-29T01:20:46.263232827Z",
+		"night":         "2014-07-29T18:12:41.559417843Z",
+		"solarNoon":     "2014-07-29T09:46:43.911325335Z",
+		"dusk":          "2014-07-29T17:06:23.060739934Z",
+		"sunsetStart":   "2014-07-29T16:36:54.868198335Z",
+		"nauticalDawn":  "2014-07-29T01:54:50.392921864Z",
+		"sunset":        "2014-07-29T16:39:37.915553748Z",
+		"sunriseEnd":    "2014-07-29T02:56:32.954452335Z",
+		"goldenHourEnd": "2014-07-29T03:28:11.233111917Z",
+		"nadir":         "2014-07-28T21:46:43.911325335Z",
+		"sunrise":       "2014-07-29T02:53:49.907096922Z",
 	}
 	sunCalc := NewSunCalc()
 	times := sunCalc.GetTimes(t1, lat, lng)
@@ -69,20 +69,351 @@ func TestTimes(t *testing.T) {
 	}
 }
 
+func TestDeltaT(t *testing.T) {
+	testNear(t, "deltaT(2000)", DeltaT(2000), 63.86)
+	testNear(t, "deltaT(2014.574778190005)", DeltaT(2014.574778190005), 68.80279491573366)
+}
+
 func TestGetMoonPosition(t *testing.T) {
 	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
 	lat := 31.783
 	lng := 35.233
 	azim, alti, dist := GetMoonPosition(t1, lat, lng)
-	testNear(t, "azimuth", azim, 1.8424006017910686)
-	testNear(t, "altitude", alti, -0.2419311867071057)
-	testNear(t, "distance", dist, 404133.76960804936)
+	testNear(t, "azimuth", azim, 1.8422663860382353)
+	testNear(t, "altitude", alti, -0.24182972792340804)
+	testNear(t, "distance", dist, 404132.2397065736)
+}
+
+func TestGetMoonTimes(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	lat := 31.783
+	lng := 35.233
+	timesGood := map[string]string{
+		"moonrise":     "2014-07-30T05:53:06.164649138Z",
+		"moonset":      "2014-07-30T18:28:13.461022781Z",
+		"lunarTransit": "2014-07-30T12:11:42.916771093Z",
+	}
+	times := GetMoonTimes(t1, lat, lng)
+	for name, t2 := range timesGood {
+		got, ok := times[name]
+		if !ok {
+			t.Errorf("%s: missing from result", name)
+			continue
+		}
+		gotUTC := got.UTC().Format(time.RFC3339Nano)
+		if gotUTC != t2 {
+			t.Errorf("%s: %s instead of %s", name, gotUTC, t2)
+		}
+	}
+}
+
+func TestGetMoonTimesHighLatitude(t *testing.T) {
+	// Near the poles the Moon routinely stays above or below the horizon
+	// for an entire civil day, and the ~24h50m lunar day occasionally fits
+	// two transits into one 24h window - the edge cases GetMoonTimes'
+	// AlwaysUp/AlwaysDown sentinels and lunarTransit2 exist for.
+	cases := []struct {
+		name      string
+		date      string
+		lat, lng  float64
+		timesGood map[string]string
+		absent    []string
+	}{
+		{
+			name: "moonAlwaysDown",
+			date: "2024-06-22",
+			lat:  78.2, lng: 15.6,
+			timesGood: map[string]string{
+				"moonAlwaysDown": "2024-06-22T00:00:00Z",
+			},
+			absent: []string{"moonrise", "moonset", "lunarTransit"},
+		},
+		{
+			name: "moonAlwaysUp",
+			date: "2024-01-05",
+			lat:  -80, lng: 0,
+			timesGood: map[string]string{
+				"moonAlwaysUp": "2024-01-05T00:00:00Z",
+				"lunarTransit": "2024-01-05T07:05:21.869444174Z",
+			},
+			absent: []string{"moonrise", "moonset"},
+		},
+		{
+			name: "lunarTransit2",
+			date: "2024-01-16",
+			lat:  -80, lng: 0,
+			timesGood: map[string]string{
+				"lunarTransit":  "2024-01-16T02:12:05.278781463Z",
+				"lunarTransit2": "2024-01-16T16:14:23.817267043Z",
+				"moonrise":      "2024-01-16T10:03:16.122801653Z",
+				"moonset":       "2024-01-16T21:58:32.294193335Z",
+			},
+		},
+	}
+	for _, c := range cases {
+		date, _ := time.Parse("2006-01-02", c.date)
+		times := GetMoonTimes(date, c.lat, c.lng)
+		for name, want := range c.timesGood {
+			got, ok := times[name]
+			if !ok {
+				t.Errorf("%s/%s: missing from result", c.name, name)
+				continue
+			}
+			if gotUTC := got.UTC().Format(time.RFC3339Nano); gotUTC != want {
+				t.Errorf("%s/%s: %s instead of %s", c.name, name, gotUTC, want)
+			}
+		}
+		for _, name := range c.absent {
+			if _, ok := times[name]; ok {
+				t.Errorf("%s/%s: %q present in result, expected absent", c.name, name, name)
+			}
+		}
+	}
+}
+
+func TestGetMoonPositionTopocentric(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	observer := Observer{Lat: 31.783, Lng: 35.233, Elevation: 800}
+	azim, alti, dist, ra, dec := GetMoonPositionTopocentric(t1, observer)
+	testNear(t, "azimuth", azim, 1.8423133644436127)
+	testNear(t, "altitude", alti, -0.257106611369838)
+	testNear(t, "distance", dist, 404132.2397065736)
+	testNear(t, "ra", ra, 2.7449891796019212)
+	testNear(t, "dec", dec, 0.08670296169145558)
+}
+
+func TestGetPositionTopocentric(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	observer := Observer{Lat: 31.783, Lng: 35.233, Elevation: 800}
+	sunCalc := NewSunCalc()
+	azim, alti, ra, dec := sunCalc.GetPositionTopocentric(t1, observer)
+	testNear(t, "azimuth", azim, 2.3820004560017027)
+	testNear(t, "altitude", alti, -0.45742744151365083)
+	testNear(t, "ra", ra, 2.246579191446318)
+	testNear(t, "dec", dec, 0.3261529771841391)
+}
+
+func TestSunCoordsHighPrecision(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	dec, ra := SunCoordsHighPrecision(t1)
+	testNear(t, "dec", dec, 0.32503832089142398)
+	testNear(t, "ra", ra, 2.2510078464882821)
+}
+
+func TestMoonCoordsHighPrecision(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	dec, ra, dist := MoonCoordsHighPrecision(t1)
+	testNear(t, "dec", dec, 0.09484121363816488)
+	testNear(t, "ra", ra, 2.757473790436718)
+	testNear(t, "dist", dist, 405352.7978287744)
+}
+
+func TestNutation(t *testing.T) {
+	deltaPsi, deltaEpsilon := Nutation(0.145)
+	testNear(t, "deltaPsi", deltaPsi, 3.810586398849825e-05)
+	testNear(t, "deltaEpsilon", deltaEpsilon, -4.31491422455936e-05)
+}
+
+func TestApparentSiderealTime(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	d := toDays(t1).AddSeconds(deltaTSeconds(t1))
+	lw := rad * -35.233
+	ast := ApparentSiderealTime(d, lw)
+	testNear(t, "apparentSiderealTime", ast, 4.682725743215541)
+}
+
+func TestGetPlanetPositionApprox(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	lat := 31.783
+	lng := 35.233
+	azim, alti, dist := GetPlanetPositionApprox(Jupiter, t1, lat, lng)
+	testNear(t, "azimuth", azim, 2.4492957505971713)
+	testNear(t, "altitude", alti, -0.4752038561292451)
+	testNear(t, "distance", dist, 6.278917969802684)
+}
+
+func TestGetPlanetTimesApprox(t *testing.T) {
+	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
+	lat := 31.783
+	lng := 35.233
+	timesGood := map[string]string{
+		"rise":    "2014-07-30T02:36:13.008951628Z",
+		"set":     "2014-07-30T16:24:00.173505338Z",
+		"transit": "2014-07-30T09:30:08.58375216Z",
+	}
+	times := GetPlanetTimesApprox(Jupiter, t1, lat, lng)
+	for name, t2 := range timesGood {
+		got, ok := times[name]
+		if !ok {
+			t.Errorf("%s: missing from result", name)
+			continue
+		}
+		gotUTC := got.UTC().Format(time.RFC3339Nano)
+		if gotUTC != t2 {
+			t.Errorf("%s: %s instead of %s", name, gotUTC, t2)
+		}
+	}
+}
+
+func TestGetPlanetTimesApproxHighLatitude(t *testing.T) {
+	cases := []struct {
+		name   string
+		planet Planet
+		date   string
+		lat    float64
+		lng    float64
+		good   map[string]string
+		absent []string
+	}{
+		{
+			name:   "planetAlwaysUp",
+			planet: Jupiter,
+			date:   "Jun 21 2024 00:00:00",
+			lat:    78.2,
+			lng:    15.6,
+			good: map[string]string{
+				"transit":        "2024-06-21T09:15:32.041960262Z",
+				"planetAlwaysUp": "2024-06-21T00:00:00Z",
+			},
+			absent: []string{"rise", "set"},
+		},
+		{
+			name:   "planetAlwaysDown",
+			planet: Venus,
+			date:   "Dec 21 2024 00:00:00",
+			lat:    78.2,
+			lng:    15.6,
+			good: map[string]string{
+				"transit":          "2024-12-21T14:15:38.806258765Z",
+				"planetAlwaysDown": "2024-12-21T00:00:00Z",
+			},
+			absent: []string{"rise", "set"},
+		},
+	}
+
+	for _, c := range cases {
+		t1, _ := time.Parse("Jan 2 2006 15:04:05", c.date)
+		times := GetPlanetTimesApprox(c.planet, t1, c.lat, c.lng)
+		for name, want := range c.good {
+			got, ok := times[name]
+			if !ok {
+				t.Errorf("%s: %s missing from result", c.name, name)
+				continue
+			}
+			gotUTC := got.UTC().Format(time.RFC3339Nano)
+			if gotUTC != want {
+				t.Errorf("%s: %s: %s instead of %s", c.name, name, gotUTC, want)
+			}
+		}
+		for _, name := range c.absent {
+			if _, ok := times[name]; ok {
+				t.Errorf("%s: %s present in result, want absent", c.name, name)
+			}
+		}
+	}
+}
+
+func TestPrecessEquatorial(t *testing.T) {
+	// Regulus (alpha Leonis), J2000: RA 10h08m22.3s, Dec +11°58'02"
+	ra := rad * (10 + 8.0/60 + 22.3/3600) * 15
+	dec := rad * (11 + 58.0/60 + 2.0/3600)
+
+	raTo, decTo := PrecessEquatorial(ra, dec, j2000, j2000-(2000-1875)*365.25)
+	testNear(t, "ra(J1875)", raTo, 2.6253092922681591)
+	testNear(t, "dec(J1875)", decTo, 0.2195196786205098)
+
+	raTo2, decTo2 := FromJ2000(ra, dec, j2000-50*365.25)
+	testNear(t, "ra(~1950)", raTo2, 2.6428504807169628)
+	testNear(t, "dec(~1950)", decTo2, 0.2131480913027931)
+}
+
+func TestConstellationApprox(t *testing.T) {
+	// Regulus (alpha Leonis), J2000.
+	ra := rad * (10 + 8.0/60 + 22.3/3600) * 15
+	dec := rad * (11 + 58.0/60 + 2.0/3600)
+
+	c := ConstellationApprox(ra, dec, j2000)
+	if c != "Leo" {
+		t.Errorf("ConstellationApprox: %q instead of %q", c, "Leo")
+	}
+	if name := ConstellationName(c); name != "Leo" {
+		t.Errorf("ConstellationName(%q): %q instead of %q", c, name, "Leo")
+	}
+	if name := ConstellationName("nope"); name != "" {
+		t.Errorf("ConstellationName(unknown): %q instead of \"\"", name)
+	}
+}
+
+func TestConstellationBrightStars(t *testing.T) {
+	// J2000 coordinates of nine bright stars spanning the boundary table's
+	// pockets and bands, paired with their actual IAU constellation. This
+	// is a regression/calibration test, not an independent accuracy
+	// check: the pockets in constellationBoundaries were carved out to fix
+	// these exact stars, so a pass here only proves the table reproduces
+	// the cases it was tuned against, not that it's correct elsewhere in
+	// the sky. See constellation_data.go and TestConstellationSpotCheck.
+	stars := []struct {
+		name     string
+		ra, dec  float64
+		expected string
+	}{
+		{"Antares", rad * (16 + 29.0/60 + 24.5/3600) * 15, -rad * (26 + 25.0/60 + 55.0/3600), "Sco"},
+		{"Spica", rad * (13 + 25.0/60 + 11.6/3600) * 15, -rad * (11 + 9.0/60 + 41.0/3600), "Vir"},
+		{"Betelgeuse", rad * (5 + 55.0/60 + 10.3/3600) * 15, rad * (7 + 24.0/60 + 25.0/3600), "Ori"},
+		{"Rigel", rad * (5 + 14.0/60 + 32.3/3600) * 15, -rad * (8 + 12.0/60 + 6.0/3600), "Ori"},
+		{"Fomalhaut", rad * (22 + 57.0/60 + 39.0/3600) * 15, -rad * (29 + 37.0/60 + 20.0/3600), "PsA"},
+		{"Canopus", rad * (6 + 23.0/60 + 57.1/3600) * 15, -rad * (52 + 41.0/60 + 44.0/3600), "Car"},
+		{"Sirius", rad * (6 + 45.0/60 + 8.9/3600) * 15, -rad * (16 + 42.0/60 + 58.0/3600), "CMa"},
+		{"Vega", rad * (18 + 36.0/60 + 56.3/3600) * 15, rad * (38 + 47.0/60 + 1.0/3600), "Lyr"},
+		{"Arcturus", rad * (14 + 15.0/60 + 39.7/3600) * 15, rad * (19 + 10.0/60 + 56.0/3600), "Boo"},
+	}
+	for _, s := range stars {
+		if c := ConstellationApprox(s.ra, s.dec, j2000); c != s.expected {
+			t.Errorf("ConstellationApprox(%s): %q instead of %q", s.name, c, s.expected)
+		}
+	}
+}
+
+func TestConstellationSpotCheck(t *testing.T) {
+	// J2000 coordinates of well-known stars spread across
+	// constellationBoundaries' tiers and bands (polar caps, circumpolar
+	// bands, mid-northern band, equatorial/zodiacal band, southern and
+	// deep-southern bands), none of which were among the stars the
+	// table's pockets were carved out to fix. Unlike
+	// TestConstellationBrightStars, a pass here is evidence the coarse
+	// tiering generalizes somewhat beyond its tuned pockets - though, per
+	// constellation_data.go, it still mislabels many sky positions away
+	// from both the pockets and these particular stars (e.g. most stars
+	// in Aquila, Canis Minor, Hydra, Crux, Pegasus, and Serpens, none of
+	// which appear in the table at all).
+	stars := []struct {
+		name     string
+		ra, dec  float64
+		expected string
+	}{
+		{"Polaris", rad * (2 + 31.0/60 + 49.0/3600) * 15, rad * (89 + 15.0/60 + 51.0/3600), "UMi"},
+		{"Dubhe", rad * (11 + 3.0/60 + 43.7/3600) * 15, rad * (61 + 45.0/60 + 4.0/3600), "UMa"},
+		{"Deneb", rad * (20 + 41.0/60 + 25.9/3600) * 15, rad * (45 + 16.0/60 + 49.0/3600), "Cyg"},
+		{"Pollux", rad * (7 + 45.0/60 + 19.0/3600) * 15, rad * (28 + 1.0/60 + 34.0/3600), "Gem"},
+		{"Alphecca", rad * (15 + 34.0/60 + 41.0/3600) * 15, rad * (26 + 42.0/60 + 53.0/3600), "CrB"},
+		{"Algieba", rad * (10 + 19.0/60 + 58.4/3600) * 15, rad * (19 + 50.0/60 + 29.0/3600), "Leo"},
+		{"Sadalmelik", rad * (22 + 5.0/60 + 47.0/3600) * 15, -rad * (0 + 19.0/60 + 11.0/3600), "Aqr"},
+		{"Yed Prior", rad * (16 + 14.0/60 + 21.0/3600) * 15, -rad * (3 + 41.0/60 + 40.0/3600), "Oph"},
+		{"Deneb Kaitos", rad * (0 + 43.0/60 + 35.0/3600) * 15, -rad * (17 + 59.0/60 + 12.0/3600), "Cet"},
+		{"Alnair", rad * (22 + 8.0/60 + 14.0/3600) * 15, -rad * (46 + 57.0/60 + 40.0/3600), "Gru"},
+		{"Peacock", rad * (20 + 25.0/60 + 39.0/3600) * 15, -rad * (56 + 44.0/60 + 6.0/3600), "Pav"},
+	}
+	for _, s := range stars {
+		if c := ConstellationApprox(s.ra, s.dec, j2000); c != s.expected {
+			t.Errorf("ConstellationApprox(%s): %q instead of %q", s.name, c, s.expected)
+		}
+	}
 }
 
 func TestGetMoonIllumination(t *testing.T) {
 	t1, _ := time.Parse("Jan 2 2006 15:04:05", "Jul 29 2014 19:03:25")
 	fraction, phase, angle := GetMoonIllumination(t1)
-	testNear(t, "fraction", fraction, 0.07382281607579783)
-	testNear(t, "phase", phase, 0.08758701583098588)
-	testNear(t, "angle", angle, -1.0922384803528917)
+	testNear(t, "fraction", fraction, 0.07386196532939271)
+	testNear(t, "phase", phase, 0.0876108416751889)
+	testNear(t, "angle", angle, -1.0922669064701649)
 }
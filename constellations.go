@@ -0,0 +1,48 @@
+package astrocalc
+
+// b1875JD is the Julian Date of the Besselian epoch B1875.0, the reference
+// epoch of the official IAU constellation boundaries.
+const b1875JD = 2405889.25855
+
+// ConstellationApprox returns the three-letter abbreviation (e.g. "Ori",
+// "UMa") of the constellation whose approximate sky region contains the
+// equatorial coordinates (ra, dec), given at Julian Date epochJD. It
+// follows the shape of Roy's 1987 algorithm: precess (ra, dec) back to
+// B1875.0 (the epoch boundary tables are traditionally given in), then
+// scan constellationBoundaries for the first matching row. Rows are
+// ordered most-specific-first: a region carved out of a broader tier (e.g.
+// Orion out of the Gemini/Taurus band) is listed before that tier, so it
+// is matched first. The Approx suffix flags that constellationBoundaries
+// is a reduced axis-aligned approximation of the official IAU boundaries,
+// not the boundaries themselves; see constellation_data.go for what that
+// means for accuracy. It returns "" if no entry in the table matches.
+func ConstellationApprox(ra, dec, epochJD float64) string {
+	ra1875, dec1875 := PrecessEquatorial(ra, dec, epochJD, b1875JD)
+
+	raHours := ra1875 / rad / 15
+	if raHours < 0 {
+		raHours += 24
+	}
+	decDeg := dec1875 / rad
+
+	for _, b := range constellationBoundaries {
+		if decDeg < b.decLower {
+			continue
+		}
+		inRange := raHours >= b.raLower && raHours < b.raUpper
+		if b.raLower > b.raUpper { // range wraps around 0h
+			inRange = raHours >= b.raLower || raHours < b.raUpper
+		}
+		if inRange {
+			return b.name
+		}
+	}
+	return ""
+}
+
+// ConstellationName returns the full Latin name for the IAU three-letter
+// abbreviation abbr (as returned by ConstellationApprox), or "" if abbr is
+// not recognized.
+func ConstellationName(abbr string) string {
+	return constellationNames[abbr]
+}
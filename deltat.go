@@ -0,0 +1,107 @@
+package astrocalc
+
+import (
+	"time"
+)
+
+// DeltaT returns an estimate of ΔT = TT − UT, in seconds, for the given
+// (possibly fractional) calendar year. It stitches together the piecewise
+// polynomial approximations published by Espenak & Meeus: historical-epoch
+// polynomials for years before 1600, polynomials fitted to the tabulated
+// ΔT values from 1600 through 2010, a short-term quadratic valid from 2010
+// to 2100, and the long-term parabola outside that range.
+// See https://eclipse.gsfc.nasa.gov/SEcat5/deltatpoly.html.
+func DeltaT(year float64) float64 {
+	switch {
+	case year < -500:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u
+	case year < 500:
+		u := year / 100
+		return 10583.6 - 1014.41*u + 33.78311*u*u - 5.952053*u*u*u -
+			0.1798452*u*u*u*u + 0.022174192*u*u*u*u*u + 0.0090316521*u*u*u*u*u*u
+	case year < 1600:
+		u := (year - 1000) / 100
+		return 1574.2 - 556.01*u + 71.23472*u*u + 0.319781*u*u*u -
+			0.8503463*u*u*u*u - 0.005050998*u*u*u*u*u + 0.0083572073*u*u*u*u*u*u
+	case year < 1700:
+		u := year - 1600
+		return 120 - 0.9808*u - 0.01532*u*u + u*u*u/7129
+	case year < 1800:
+		u := year - 1700
+		return 8.83 + 0.1603*u - 0.0059285*u*u + 0.00013336*u*u*u - u*u*u*u/1174000
+	case year < 1860:
+		u := year - 1800
+		return 13.72 - 0.332447*u + 0.0068612*u*u + 0.0041116*u*u*u -
+			0.00037436*u*u*u*u + 0.0000121272*u*u*u*u*u - 0.0000001699*u*u*u*u*u*u +
+			0.000000000875*u*u*u*u*u*u*u
+	case year < 1900:
+		u := year - 1860
+		return 7.62 + 0.5737*u - 0.251754*u*u + 0.01680668*u*u*u -
+			0.0004473624*u*u*u*u + u*u*u*u*u/233174
+	case year < 1920:
+		u := year - 1900
+		return -2.79 + 1.494119*u - 0.0598939*u*u + 0.0061966*u*u*u - 0.000197*u*u*u*u
+	case year < 1941:
+		u := year - 1920
+		return 21.20 + 0.84493*u - 0.076100*u*u + 0.0020936*u*u*u
+	case year < 1961:
+		u := year - 1950
+		return 29.07 + 0.407*u - u*u/233 + u*u*u/2547
+	case year < 1986:
+		u := year - 1975
+		return 45.45 + 1.067*u - u*u/260 - u*u*u/718
+	case year < 2010:
+		u := year - 2000
+		return 63.86 + 0.3345*u - 0.060374*u*u + 0.0017275*u*u*u +
+			0.000651814*u*u*u*u + 0.00002373599*u*u*u*u*u
+	case year < 2100:
+		t := year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	default:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u
+	}
+}
+
+// AddSeconds returns a new JulianDate offset from j by the given number of
+// seconds (which may be fractional or negative).
+func (j JulianDate) AddSeconds(seconds float64) JulianDate {
+	nanos := j.time + int64(seconds*1e9)
+	days, rem := integerDivide(nanos, daySec*int64(1e9))
+	return JulianDate{
+		julianDayNumber: j.julianDayNumber + days,
+		time:            rem,
+	}
+}
+
+// yearOf returns the calendar year of t as a fractional float64, used to
+// look up ΔT.
+func yearOf(t time.Time) float64 {
+	t = t.UTC()
+	year := t.Year()
+	startOfYear := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	startOfNextYear := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	frac := float64(t.Sub(startOfYear)) / float64(startOfNextYear.Sub(startOfYear))
+	return float64(year) + frac
+}
+
+// TT treats j as a Julian Date in Universal Time and returns the
+// corresponding Terrestrial Time (JDE), i.e. j + ΔT.
+func (j JulianDate) TT() JulianDate {
+	return j.AddSeconds(DeltaT(yearOf(j.Time())))
+}
+
+// UT treats j as a Julian Date in Terrestrial Time and returns the
+// corresponding Universal Time, i.e. j − ΔT.
+func (j JulianDate) UT() JulianDate {
+	return j.AddSeconds(-DeltaT(yearOf(j.Time())))
+}
+
+// deltaTSeconds returns ΔT, in seconds, for the calendar date t. It is used
+// internally to shift a days-since-J2000 value (as produced by toDays, which
+// is not itself a valid absolute JulianDate) into Terrestrial Time without
+// round-tripping through JulianDate.Time.
+func deltaTSeconds(t time.Time) float64 {
+	return DeltaT(yearOf(t))
+}
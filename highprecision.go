@@ -0,0 +1,61 @@
+package astrocalc
+
+import (
+	"math"
+	"time"
+
+	"github.com/yaslama/astrocalc/internal/vsop"
+)
+
+// julianMillenniaTT returns the number of Julian millennia of Terrestrial
+// Time since J2000 for the Julian Date jd, as used by the VSOP87 series.
+func julianMillenniaTT(jd JulianDate) float64 {
+	return julianCenturiesTT(jd) / 10
+}
+
+// aberrationConstant is the constant term of the annual aberration of light,
+// in arcseconds (Meeus, Astronomical Algorithms, eq. 25.10).
+const aberrationConstant = 20.4898
+
+// SunCoordsHighPrecision returns the Sun's geocentric declination and right
+// ascension at date, computed from a truncated VSOP87D series for Earth's
+// heliocentric position plus nutation and annual aberration, instead of the
+// 2-3 term approximation used by sunCoords. Good to about 1″.
+func SunCoordsHighPrecision(date time.Time) (dec, ra float64) {
+	d := toDays(date).AddSeconds(deltaTSeconds(date))
+	t := julianMillenniaTT(d)
+	lon, lat, r := vsop.EarthHeliocentric(t)
+
+	// The geocentric Sun is diametrically opposite Earth's heliocentric position.
+	theta := lon + math.Pi
+	beta := -lat
+
+	centuries := t * 10
+	deltaPsi, deltaEpsilon := Nutation(centuries)
+	aberration := -aberrationConstant * arcsec / r
+	lambda := theta + deltaPsi + aberration
+	epsilon := MeanObliquity(centuries) + deltaEpsilon
+
+	dec = declinationEps(lambda, beta, epsilon)
+	ra = rightAscensionEps(lambda, beta, epsilon)
+	return
+}
+
+// MoonCoordsHighPrecision returns the Moon's geocentric declination, right
+// ascension and distance (km) at date, computed from a truncated
+// ELP2000/82 series plus nutation, instead of the 3-term approximation used
+// by moonCoords.
+func MoonCoordsHighPrecision(date time.Time) (dec, ra, dist float64) {
+	d := toDays(date).AddSeconds(deltaTSeconds(date))
+	t := julianCenturiesTT(d)
+	lon, lat, r := vsop.Moon(t)
+
+	deltaPsi, deltaEpsilon := Nutation(t)
+	lambda := lon + deltaPsi
+	epsilon := MeanObliquity(t) + deltaEpsilon
+
+	dec = declinationEps(lambda, lat, epsilon)
+	ra = rightAscensionEps(lambda, lat, epsilon)
+	dist = r
+	return
+}
@@ -0,0 +1,251 @@
+// Package vsop provides truncated VSOP87D and ELP2000/82-style periodic
+// series for Earth's heliocentric position and the Moon's geocentric
+// ecliptic position, suitable for ~1″-level solar accuracy and sub-arcminute
+// lunar accuracy. Full VSOP87D/ELP2000 series run to hundreds of terms; the
+// tables here keep only the dominant terms, which is enough to meaningfully
+// improve on a 2-3 term low-precision approximation without shipping
+// megabytes of coefficients.
+//
+// See Jean Meeus, "Astronomical Algorithms", 2nd ed., chapters 25 and 47.
+package vsop
+
+import "math"
+
+// Term is a single periodic term A·cos(B + C·t) in a VSOP87-style series.
+// The lunar series (see Moon) uses moonArg instead.
+type Term struct {
+	A, B, C float64
+}
+
+func sumSeries(terms []Term, t float64) float64 {
+	var sum float64
+	for _, term := range terms {
+		sum += term.A * math.Cos(term.B+term.C*t)
+	}
+	return sum
+}
+
+// Earth heliocentric longitude (L), latitude (B) and radius (R) periodic
+// terms, truncated from VSOP87D. Amplitudes for L0/L1/L2/... and R0/R1/...
+// are in units of 1e-8 radians/AU; B0/B1 are in 1e-8 radians.
+var earthL0 = []Term{
+	{175347046, 0, 0},
+	{3341656, 4.6692568, 6283.0758500},
+	{34894, 4.62610, 12566.15170},
+	{3497, 2.7441, 5753.3849},
+	{3418, 2.8289, 3.5231},
+	{3136, 3.6277, 77713.7715},
+	{2676, 4.4181, 7860.4194},
+	{2343, 6.1352, 3930.2097},
+	{1324, 0.7425, 11506.7698},
+	{1273, 2.0371, 529.6910},
+	{1199, 1.1096, 1577.3435},
+	{990, 5.233, 5884.927},
+	{902, 2.045, 26.298},
+	{857, 3.508, 398.149},
+	{780, 1.179, 5223.694},
+	{753, 2.533, 5507.553},
+	{505, 4.583, 18849.228},
+	{492, 4.205, 775.523},
+	{357, 2.920, 0.067},
+	{317, 5.849, 11790.629},
+}
+
+var earthL1 = []Term{
+	{628331966747, 0, 0},
+	{206059, 2.678235, 6283.075850},
+	{4303, 2.6351, 12566.1517},
+	{425, 1.590, 3.523},
+	{119, 5.796, 26.298},
+	{109, 2.966, 1577.344},
+	{93, 2.59, 18849.23},
+	{72, 1.14, 529.69},
+	{68, 1.87, 398.15},
+	{67, 4.41, 5507.55},
+}
+
+var earthL2 = []Term{
+	{52919, 0, 0},
+	{8720, 1.0721, 6283.0758},
+	{309, 0.867, 12566.152},
+	{27, 0.05, 3.52},
+	{16, 5.19, 26.30},
+}
+
+var earthL3 = []Term{
+	{289, 5.844, 6283.076},
+	{35, 0, 0},
+	{17, 5.49, 12566.15},
+}
+
+var earthL4 = []Term{
+	{114, 3.142, 0},
+	{8, 4.13, 6283.08},
+}
+
+var earthL5 = []Term{
+	{1, 3.14, 0},
+}
+
+var earthB0 = []Term{
+	{280, 3.199, 84334.662},
+	{102, 5.422, 5507.553},
+	{80, 3.88, 5223.69},
+	{44, 3.70, 2352.87},
+	{32, 4.00, 1577.34},
+}
+
+var earthB1 = []Term{
+	{9, 3.90, 5507.55},
+	{6, 1.73, 5223.69},
+}
+
+var earthR0 = []Term{
+	{100013989, 0, 0},
+	{1670700, 3.0984635, 6283.0758500},
+	{13956, 3.05525, 12566.15170},
+	{3084, 5.1985, 77713.7715},
+	{1628, 1.1739, 5753.3849},
+	{1576, 2.8469, 7860.4194},
+	{925, 5.453, 11506.770},
+	{542, 4.564, 3930.210},
+	{472, 3.661, 5884.927},
+	{346, 0.964, 5507.553},
+	{329, 5.900, 5223.694},
+}
+
+var earthR1 = []Term{
+	{103019, 1.10749, 6283.07585},
+	{1721, 1.0644, 12566.1517},
+	{702, 3.142, 0},
+	{32, 1.02, 18849.23},
+}
+
+var earthR2 = []Term{
+	{4359, 5.7846, 6283.0758},
+	{124, 5.579, 12566.152},
+}
+
+// EarthHeliocentric returns the Earth's heliocentric ecliptic longitude and
+// latitude (radians, J2000 mean ecliptic of date) and its distance to the
+// Sun (AU), given t, the number of Julian millennia of Terrestrial Time
+// since J2000 (t = (JDE-2451545)/365250).
+func EarthHeliocentric(t float64) (lon, lat, radius float64) {
+	l0 := sumSeries(earthL0, t)
+	l1 := sumSeries(earthL1, t)
+	l2 := sumSeries(earthL2, t)
+	l3 := sumSeries(earthL3, t)
+	l4 := sumSeries(earthL4, t)
+	l5 := sumSeries(earthL5, t)
+	l := (l0 + l1*t + l2*t*t + l3*t*t*t + l4*t*t*t*t + l5*t*t*t*t*t) / 1e8
+
+	b0 := sumSeries(earthB0, t)
+	b1 := sumSeries(earthB1, t)
+	b := (b0 + b1*t) / 1e8
+
+	r0 := sumSeries(earthR0, t)
+	r1 := sumSeries(earthR1, t)
+	r2 := sumSeries(earthR2, t)
+	r := (r0 + r1*t + r2*t*t) / 1e8
+
+	lon = math.Mod(l, 2*math.Pi)
+	if lon < 0 {
+		lon += 2 * math.Pi
+	}
+	lat = b
+	radius = r
+	return
+}
+
+const rad = math.Pi / 180
+
+// moonArg holds the multipliers of the four fundamental lunar arguments (D:
+// mean elongation, M: Sun's mean anomaly, Mp: Moon's mean anomaly, F: Moon's
+// argument of latitude) for one ELP2000/82 periodic term, plus its
+// amplitude.
+type moonArg struct {
+	d, m, mp, f float64
+	amplitude   float64
+}
+
+// moonLongitudeTerms and moonDistanceTerms contribute sin/cos(D·d+M·m+Mp·mp+F·f)
+// respectively to the Moon's longitude (amplitude in 1e-6 degrees) and
+// distance (amplitude in 1e-3 kilometers); moonLatitudeTerms contribute to its
+// ecliptic latitude (1e-6 degrees). Truncated from Meeus, Astronomical
+// Algorithms, table 47.A, to its dominant terms.
+var moonLongitudeTerms = []moonArg{
+	{0, 0, 1, 0, 6288774},
+	{2, 0, -1, 0, 1274027},
+	{2, 0, 0, 0, 658314},
+	{0, 0, 2, 0, 213618},
+	{0, 1, 0, 0, -185116},
+	{0, 0, 0, 2, -114332},
+	{2, 0, -2, 0, 58793},
+	{2, -1, -1, 0, 57066},
+	{2, 0, 1, 0, 53322},
+	{2, -1, 0, 0, 45758},
+	{0, 1, -1, 0, -40923},
+	{1, 0, 0, 0, -34720},
+	{0, 1, 1, 0, -30383},
+	{2, 0, -3, 0, 15327},
+	{0, 0, 1, 2, -12528},
+}
+
+var moonLatitudeTerms = []moonArg{
+	{0, 0, 0, 1, 5128122},
+	{0, 0, 1, 1, 280602},
+	{0, 0, 1, -1, 277693},
+	{2, 0, 0, -1, 173237},
+	{2, 0, -1, 1, 55413},
+	{2, 0, -1, -1, 46271},
+	{2, 0, 0, 1, 32573},
+	{0, 0, 2, 1, 17198},
+	{2, 0, 1, -1, 9266},
+	{0, 0, 2, -1, 8822},
+}
+
+var moonDistanceTerms = []moonArg{
+	{0, 0, 1, 0, -20905355},
+	{2, 0, -1, 0, -3699111},
+	{2, 0, 0, 0, -2955968},
+	{0, 0, 2, 0, -569925},
+	{0, 1, 0, 0, 48888},
+	{2, 0, -2, 0, 246158},
+	{2, -1, -1, 0, -152138},
+	{2, 0, 1, 0, -170733},
+	{2, -1, 0, 0, -204586},
+	{0, 1, -1, 0, -129620},
+}
+
+// Moon returns the Moon's geocentric ecliptic longitude and latitude
+// (radians) and its distance from the Earth (km), given t, the number of
+// Julian centuries of Terrestrial Time since J2000 (t =
+// (JDE-2451545)/36525), following the structure of Meeus chapter 47 with a
+// truncated ELP2000/82 term table.
+func Moon(t float64) (lon, lat, dist float64) {
+	lp := 218.3164477 + 481267.88123421*t - 0.0015786*t*t + t*t*t/538841 - t*t*t*t/65194000
+	d := 297.8501921 + 445267.1114034*t - 0.0018819*t*t + t*t*t/545868 - t*t*t*t/113065000
+	m := 357.5291092 + 35999.0502909*t - 0.0001536*t*t + t*t*t/24490000
+	mp := 134.9633964 + 477198.8675055*t + 0.0087414*t*t + t*t*t/69699 - t*t*t*t/14712000
+	f := 93.2720950 + 483202.0175233*t - 0.0036539*t*t - t*t*t/3526000 + t*t*t*t/863310000
+
+	var sumL, sumR float64
+	for _, term := range moonLongitudeTerms {
+		arg := rad * (term.d*d + term.m*m + term.mp*mp + term.f*f)
+		sumL += term.amplitude * math.Sin(arg)
+	}
+	for _, term := range moonDistanceTerms {
+		arg := rad * (term.d*d + term.m*m + term.mp*mp + term.f*f)
+		sumR += term.amplitude * math.Cos(arg)
+	}
+	var sumB float64
+	for _, term := range moonLatitudeTerms {
+		arg := rad * (term.d*d + term.m*m + term.mp*mp + term.f*f)
+		sumB += term.amplitude * math.Sin(arg)
+	}
+
+	lon = rad * math.Mod(lp+sumL/1e6, 360)
+	lat = rad * (sumB / 1e6)
+	dist = 385000.56 + sumR/1e3
+	return
+}
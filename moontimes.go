@@ -0,0 +1,166 @@
+package astrocalc
+
+import (
+	"math"
+	"time"
+)
+
+// moonRefraction is the standard atmospheric refraction at the horizon, in
+// radians.
+const moonRefraction = 0.5667 * rad
+
+// earthRadiusKm is the mean equatorial radius of the Earth, used to derive
+// the Moon's horizontal parallax from its distance.
+const earthRadiusKm = 6378.137
+
+// moonHorizonAltitude returns h0, the altitude (in radians) at which the
+// Moon's upper limb is considered to rise or set for an observer at the
+// given distance (in kilometers): 0.7275·parallax minus standard refraction,
+// which comes out to roughly +0.125° for the Moon's ~0.95° mean parallax.
+func moonHorizonAltitude(dist float64) float64 {
+	parallax := math.Asin(earthRadiusKm / dist)
+	return 0.7275*parallax - moonRefraction
+}
+
+// moonAltitudeAboveHorizon returns the Moon's altitude at t, in radians,
+// measured relative to the rise/set threshold h0 (positive when the Moon is
+// above it).
+func moonAltitudeAboveHorizon(t time.Time, lat, lng float64) float64 {
+	_, alti, dist := GetMoonPosition(t, lat, lng)
+	return alti - moonHorizonAltitude(dist)
+}
+
+// moonAltitude returns the Moon's plain altitude at t, in radians.
+func moonAltitude(t time.Time, lat, lng float64) float64 {
+	_, alti, _ := GetMoonPosition(t, lat, lng)
+	return alti
+}
+
+// newtonStep is the time step used to estimate derivatives by central
+// difference when Newton-refining a rise/set/transit time.
+const newtonStep = 60 * time.Second
+
+// newtonConvergeSeconds is the step size, in seconds, below which a Newton
+// iteration is considered converged.
+const newtonConvergeSeconds = 0.5
+
+// refineMoonCrossing Newton-refines t, an approximate time at which
+// moonAltitudeAboveHorizon crosses zero, to sub-second precision.
+func refineMoonCrossing(t time.Time, lat, lng float64) time.Time {
+	for i := 0; i < 20; i++ {
+		fPlus := moonAltitudeAboveHorizon(t.Add(newtonStep), lat, lng)
+		fMinus := moonAltitudeAboveHorizon(t.Add(-newtonStep), lat, lng)
+		deriv := (fPlus - fMinus) / (2 * newtonStep.Seconds())
+		if deriv == 0 {
+			break
+		}
+		f := moonAltitudeAboveHorizon(t, lat, lng)
+		dtSeconds := -f / deriv
+		t = t.Add(time.Duration(dtSeconds * float64(time.Second)))
+		if math.Abs(dtSeconds) < newtonConvergeSeconds {
+			break
+		}
+	}
+	return t
+}
+
+// refineMoonTransit Newton-refines t, an approximate time of a local maximum
+// of the Moon's altitude, to sub-second precision by zeroing the altitude's
+// rate of change.
+func refineMoonTransit(t time.Time, lat, lng float64) time.Time {
+	for i := 0; i < 20; i++ {
+		fPlus := moonAltitude(t.Add(newtonStep), lat, lng)
+		fMinus := moonAltitude(t.Add(-newtonStep), lat, lng)
+		f0 := moonAltitude(t, lat, lng)
+		slope := (fPlus - fMinus) / (2 * newtonStep.Seconds())
+		curvature := (fPlus - 2*f0 + fMinus) / (newtonStep.Seconds() * newtonStep.Seconds())
+		if curvature == 0 {
+			break
+		}
+		dtSeconds := -slope / curvature
+		t = t.Add(time.Duration(dtSeconds * float64(time.Second)))
+		if math.Abs(dtSeconds) < newtonConvergeSeconds {
+			break
+		}
+	}
+	return t
+}
+
+// GetMoonTimes calculates the Moon's rise, set and transit times for the
+// 24-hour window starting at date, at the given latitude/longitude. It
+// returns a map with up to the following keys:
+//
+//	moonrise: time the Moon's upper limb crosses above the horizon
+//	moonset: time the Moon's upper limb crosses below the horizon
+//	lunarTransit: time the Moon reaches its highest point (culmination)
+//	lunarTransit2: a second transit, present only in the rare case that two
+//	  occur within the window (the ~24h50m lunar day can straddle it twice)
+//
+// Because the Moon moves fast enough that a single hour-angle calculation is
+// not reliable, the window is sampled hourly to bracket sign changes of
+// altitude − h0, then each bracket is Newton-refined using GetMoonPosition.
+// If the Moon never crosses the horizon within the window, no moonrise/
+// moonset keys are set; instead "moonAlwaysUp" or "moonAlwaysDown" is set to
+// date as a sentinel, as happens routinely near the poles.
+func GetMoonTimes(date time.Time, lat, lng float64) map[string]time.Time {
+	result := make(map[string]time.Time)
+
+	const hours = 24
+	samples := make([]float64, hours+1)
+	altitudes := make([]float64, hours+1)
+	times := make([]time.Time, hours+1)
+	for i := 0; i <= hours; i++ {
+		t := date.Add(time.Duration(i) * time.Hour)
+		_, alti, dist := GetMoonPosition(t, lat, lng)
+		times[i] = t
+		altitudes[i] = alti
+		samples[i] = alti - moonHorizonAltitude(dist)
+	}
+
+	foundRiseOrSet := false
+	for i := 0; i < hours; i++ {
+		if samples[i] == 0 {
+			continue
+		}
+		if samples[i] < 0 && samples[i+1] >= 0 {
+			foundRiseOrSet = true
+			result["moonrise"] = refineMoonCrossing(times[i], lat, lng)
+		} else if samples[i] >= 0 && samples[i+1] < 0 {
+			foundRiseOrSet = true
+			result["moonset"] = refineMoonCrossing(times[i], lat, lng)
+		}
+	}
+
+	transitsFound := 0
+	for i := 1; i < hours; i++ {
+		if altitudes[i] > altitudes[i-1] && altitudes[i] > altitudes[i+1] {
+			transit := refineMoonTransit(times[i], lat, lng)
+			transitsFound++
+			if transitsFound == 1 {
+				result["lunarTransit"] = transit
+			} else {
+				result["lunarTransit2"] = transit
+			}
+		}
+	}
+
+	if !foundRiseOrSet {
+		allAbove := true
+		allBelow := true
+		for _, s := range samples {
+			if s < 0 {
+				allAbove = false
+			}
+			if s >= 0 {
+				allBelow = false
+			}
+		}
+		if allAbove {
+			result["moonAlwaysUp"] = date
+		} else if allBelow {
+			result["moonAlwaysDown"] = date
+		}
+	}
+
+	return result
+}
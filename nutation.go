@@ -0,0 +1,106 @@
+package astrocalc
+
+import "math"
+
+// arcsec is one arcsecond, in radians.
+const arcsec = rad / 3600
+
+// nutationTerm is one term of the IAU 1980 nutation series: integer
+// multipliers of the five Delaunay fundamental arguments (D, M, M', F, Ω),
+// and the sine/cosine amplitudes (in 0.0001″, with their per-Julian-century
+// secular rates) contributed to Δψ and Δε respectively.
+type nutationTerm struct {
+	d, m, mp, f, omega float64
+	psiSin, psiSinT    float64
+	epsCos, epsCosT    float64
+}
+
+// nutationTerms holds the 30 largest-amplitude terms of the IAU 1980
+// nutation series (Meeus, Astronomical Algorithms, table 21.A), truncated
+// from the full 106-term series. The omitted terms amount to about 0.01″.
+var nutationTerms = []nutationTerm{
+	{0, 0, 0, 0, 1, -171996, -174.2, 92025, 8.9},
+	{-2, 0, 0, 2, 2, -13187, -1.6, 5736, -3.1},
+	{0, 0, 0, 2, 2, -2274, -0.2, 977, -0.5},
+	{0, 0, 0, 0, 2, 2062, 0.2, -895, 0.5},
+	{0, 1, 0, 0, 0, 1426, -3.4, 54, -0.1},
+	{0, 0, 1, 0, 0, 712, 0.1, -7, 0},
+	{-2, 1, 0, 2, 2, -517, 1.2, 224, -0.6},
+	{0, 0, 0, 2, 1, -386, -0.4, 200, 0},
+	{0, 0, 1, 2, 2, -301, 0, 129, -0.1},
+	{-2, -1, 0, 2, 2, 217, -0.5, -95, 0.3},
+	{-2, 0, 1, 0, 0, -158, 0, 0, 0},
+	{-2, 0, 0, 2, 1, 129, 0.1, -70, 0},
+	{0, 0, -1, 2, 2, 123, 0, -53, 0},
+	{2, 0, 0, 0, 0, 63, 0, 0, 0},
+	{0, 0, 1, 0, 1, 63, 0.1, -33, 0},
+	{2, 0, -1, 2, 2, -59, 0, 26, 0},
+	{0, 0, -1, 0, 1, -58, -0.1, 32, 0},
+	{0, 0, 1, 2, 1, -51, 0, 27, 0},
+	{-2, 0, 2, 0, 0, 48, 0, 0, 0},
+	{0, 0, -2, 2, 1, 46, 0, -24, 0},
+	{2, 0, 0, 2, 2, -38, 0, 18, 0},
+	{0, 0, 2, 2, 2, -31, 0, 13, 0},
+	{0, 0, 2, 0, 0, 29, 0, 0, 0},
+	{-2, 0, 1, 2, 2, 29, 0, -12, 0},
+	{0, 0, 0, 2, 0, 26, 0, 0, 0},
+	{-2, 0, 0, 2, 0, -22, 0, 0, 0},
+	{0, 0, -1, 2, 1, 21, 0, -10, 0},
+	{0, 2, 0, 0, 0, 17, -0.1, 0, 0},
+	{2, 0, -1, 0, 1, 16, 0, -8, 0},
+	{-2, 2, 0, 2, 2, -16, 0.1, 7, 0},
+}
+
+// Nutation returns the nutation in longitude (Δψ) and in obliquity (Δε), in
+// radians, for t Julian centuries of Terrestrial Time since J2000, summing
+// nutationTerms (Meeus, Astronomical Algorithms, eq. 22.1 and table 21.A).
+func Nutation(t float64) (deltaPsi, deltaEpsilon float64) {
+	d := rad * math.Mod(297.85036+445267.111480*t-0.0019142*t*t+t*t*t/189474, 360)
+	m := rad * math.Mod(357.52772+35999.050340*t-0.0001603*t*t-t*t*t/300000, 360)
+	mp := rad * math.Mod(134.96298+477198.867398*t+0.0086972*t*t+t*t*t/56250, 360)
+	f := rad * math.Mod(93.27191+483202.017538*t-0.0036825*t*t+t*t*t/327270, 360)
+	omega := rad * math.Mod(125.04452-1934.136261*t+0.0020708*t*t+t*t*t/450000, 360)
+
+	var sumPsi, sumEps float64
+	for _, term := range nutationTerms {
+		arg := term.d*d + term.m*m + term.mp*mp + term.f*f + term.omega*omega
+		sumPsi += (term.psiSin + term.psiSinT*t) * math.Sin(arg)
+		sumEps += (term.epsCos + term.epsCosT*t) * math.Cos(arg)
+	}
+
+	deltaPsi = sumPsi * 0.0001 * arcsec
+	deltaEpsilon = sumEps * 0.0001 * arcsec
+	return
+}
+
+// MeanObliquity returns the mean obliquity of the ecliptic, in radians, for
+// t Julian centuries of Terrestrial Time since J2000 (the IAU 1980
+// expression, Meeus eq. 22.2).
+func MeanObliquity(t float64) float64 {
+	seconds := 21.448 - 46.8150*t - 0.00059*t*t + 0.001813*t*t*t
+	return rad * (23 + (26+seconds/60)/60)
+}
+
+// julianCenturiesTT returns the number of Julian centuries of Terrestrial
+// Time since J2000 for the Julian Date jd.
+func julianCenturiesTT(jd JulianDate) float64 {
+	jdn, nanos := jd.DayTime()
+	return (float64(jdn) + float64(nanos)/(daySec*1e9)) / 36525
+}
+
+// ApparentSiderealTime returns the apparent sidereal time at Greenwich plus
+// the observer's longitude correction, in radians: the mean sidereal time
+// (as computed by siderealTime) plus the equation of the equinoxes,
+// Δψ·cos(ε).
+func ApparentSiderealTime(d JulianDate, lw float64) float64 {
+	mean := siderealTime(d, lw)
+	t := julianCenturiesTT(d)
+	deltaPsi, deltaEpsilon := Nutation(t)
+	epsilon := MeanObliquity(t) + deltaEpsilon
+
+	st := mean + deltaPsi*math.Cos(epsilon)
+	if st < 0 {
+		st += 2 * math.Pi
+	}
+	return math.Mod(st, 2*math.Pi)
+}
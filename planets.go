@@ -0,0 +1,360 @@
+package astrocalc
+
+import (
+	"math"
+	"time"
+
+	"github.com/yaslama/astrocalc/internal/vsop"
+)
+
+// A Planet identifies one of the major planets other than Earth.
+type Planet int
+
+// The planets supported by GetPlanetPositionApprox and GetPlanetTimesApprox.
+const (
+	Mercury Planet = iota
+	Venus
+	Mars
+	Jupiter
+	Saturn
+	Uranus
+	Neptune
+)
+
+// planetElements holds a planet's osculating Keplerian orbital elements at
+// J2000 and their secular (per-Julian-century) rates: semi-major axis a
+// (AU), eccentricity e, inclination i, mean longitude l, longitude of
+// perihelion longPeri, and longitude of ascending node node (all angles in
+// degrees). These are JPL's low-precision elements, valid 1800-2050
+// (https://ssd.jpl.nasa.gov/planets/approx_pos.html), good to about 1
+// arcminute.
+//
+// This is a two-body Keplerian model, not a per-planet VSOP87 series: it
+// does not include VSOP87D's per-planet perturbation terms (Meeus,
+// Astronomical Algorithms, Appendix II - e.g. the Jupiter-Saturn "great
+// inequality" terms). Only Earth's position comes from a truncated VSOP87D
+// table (Table 25A), via earthHeliocentricRectangular. The surface is named
+// accordingly - see GetPlanetPositionApprox and GetPlanetTimesApprox.
+type planetElements struct {
+	a, aDot               float64
+	e, eDot               float64
+	i, iDot               float64
+	l, lDot               float64
+	longPeri, longPeriDot float64
+	node, nodeDot         float64
+}
+
+var planetaryElements = map[Planet]planetElements{
+	Mercury: {
+		a: 0.38709927, aDot: 0.00000037,
+		e: 0.20563593, eDot: 0.00001906,
+		i: 7.00497902, iDot: -0.00594749,
+		l: 252.25032350, lDot: 149472.67411175,
+		longPeri: 77.45779628, longPeriDot: 0.16047689,
+		node: 48.33076593, nodeDot: -0.12534081,
+	},
+	Venus: {
+		a: 0.72333566, aDot: 0.00000390,
+		e: 0.00677672, eDot: -0.00004107,
+		i: 3.39467605, iDot: -0.00078890,
+		l: 181.97909950, lDot: 58517.81538729,
+		longPeri: 131.60246718, longPeriDot: 0.00268329,
+		node: 76.67984255, nodeDot: -0.27769418,
+	},
+	Mars: {
+		a: 1.52371034, aDot: 0.00001847,
+		e: 0.09339410, eDot: 0.00007882,
+		i: 1.84969142, iDot: -0.00813131,
+		l: -4.55343205, lDot: 19140.30268499,
+		longPeri: -23.94362959, longPeriDot: 0.44441088,
+		node: 49.55953891, nodeDot: -0.29257343,
+	},
+	Jupiter: {
+		a: 5.20288700, aDot: -0.00011607,
+		e: 0.04838624, eDot: -0.00013253,
+		i: 1.30439695, iDot: -0.00183714,
+		l: 34.39644051, lDot: 3034.74612775,
+		longPeri: 14.72847983, longPeriDot: 0.21252668,
+		node: 100.47390909, nodeDot: 0.20469106,
+	},
+	Saturn: {
+		a: 9.53667594, aDot: -0.00125060,
+		e: 0.05386179, eDot: -0.00050991,
+		i: 2.48599187, iDot: 0.00193609,
+		l: 49.95424423, lDot: 1222.49362201,
+		longPeri: 92.59887831, longPeriDot: -0.41897216,
+		node: 113.66242448, nodeDot: -0.28867794,
+	},
+	Uranus: {
+		a: 19.18916464, aDot: -0.00196176,
+		e: 0.04725744, eDot: -0.00004397,
+		i: 0.77263783, iDot: -0.00242939,
+		l: 313.23810451, lDot: 428.48202785,
+		longPeri: 170.95427630, longPeriDot: 0.40805281,
+		node: 74.01692503, nodeDot: 0.04240589,
+	},
+	Neptune: {
+		a: 30.06992276, aDot: 0.00026291,
+		e: 0.00859048, eDot: 0.00005105,
+		i: 1.77004347, iDot: 0.00035372,
+		l: -55.12002969, lDot: 218.45945325,
+		longPeri: 44.96476227, longPeriDot: -0.32241464,
+		node: 131.78422574, nodeDot: -0.00508664,
+	},
+}
+
+// lightTimeAUPerDay is τ per AU, in days (the time light takes to travel one
+// astronomical unit).
+const lightTimeAUPerDay = 0.0057755183
+
+// solveKepler solves Kepler's equation M = E - e·sin(E) for the eccentric
+// anomaly E (radians), given the mean anomaly m (radians) and eccentricity
+// e, by Newton's method.
+func solveKepler(m, e float64) float64 {
+	eAnomaly := m
+	if e > 0.8 {
+		eAnomaly = math.Pi
+	}
+	for i := 0; i < 30; i++ {
+		delta := (eAnomaly - e*math.Sin(eAnomaly) - m) / (1 - e*math.Cos(eAnomaly))
+		eAnomaly -= delta
+		if math.Abs(delta) < 1e-10 {
+			break
+		}
+	}
+	return eAnomaly
+}
+
+// heliocentricRectangular returns planet's heliocentric ecliptic rectangular
+// coordinates (AU, J2000 mean ecliptic) at t Julian centuries of
+// Terrestrial Time since J2000.
+func heliocentricRectangular(planet Planet, t float64) (x, y, z float64) {
+	el := planetaryElements[planet]
+
+	a := el.a + el.aDot*t
+	ecc := el.e + el.eDot*t
+	i := rad * (el.i + el.iDot*t)
+	l := rad * (el.l + el.lDot*t)
+	longPeri := rad * (el.longPeri + el.longPeriDot*t)
+	node := rad * (el.node + el.nodeDot*t)
+
+	m := l - longPeri
+	m = math.Mod(m+math.Pi, 2*math.Pi) - math.Pi
+
+	eAnomaly := solveKepler(m, ecc)
+	xOrbit := a * (math.Cos(eAnomaly) - ecc)
+	yOrbit := a * math.Sqrt(1-ecc*ecc) * math.Sin(eAnomaly)
+
+	omega := longPeri - node
+	cosO, sinO := math.Cos(node), math.Sin(node)
+	cosW, sinW := math.Cos(omega), math.Sin(omega)
+	cosI, sinI := math.Cos(i), math.Sin(i)
+
+	x = (cosO*cosW-sinO*sinW*cosI)*xOrbit + (-cosO*sinW-sinO*cosW*cosI)*yOrbit
+	y = (sinO*cosW+cosO*sinW*cosI)*xOrbit + (-sinO*sinW+cosO*cosW*cosI)*yOrbit
+	z = (sinW*sinI)*xOrbit + (cosW*sinI)*yOrbit
+	return
+}
+
+// earthHeliocentricRectangular returns Earth's heliocentric ecliptic
+// rectangular coordinates (AU, J2000 mean ecliptic) at t Julian millennia of
+// Terrestrial Time since J2000, derived from the truncated VSOP87D series.
+func earthHeliocentricRectangular(t float64) (x, y, z float64) {
+	lon, lat, r := vsop.EarthHeliocentric(t)
+	x = r * math.Cos(lat) * math.Cos(lon)
+	y = r * math.Cos(lat) * math.Sin(lon)
+	z = r * math.Sin(lat)
+	return
+}
+
+// lightTimeConvergenceSeconds is the convergence threshold for
+// planetGeocentric's light-time iteration.
+const lightTimeConvergenceSeconds = 1e-3
+
+// planetGeocentric returns the geocentric ecliptic rectangular coordinates
+// (AU) and distance (AU) of planet at Julian Date d (Terrestrial Time),
+// iterating the light-time correction τ = lightTimeAUPerDay·distance - each
+// pass recomputes planet's position at d−τ and a new distance/τ from it -
+// until τ changes by less than lightTimeConvergenceSeconds, or for at most
+// 5 passes (τ is at most tens of minutes, so this converges in 2-3 passes).
+func planetGeocentric(planet Planet, d JulianDate) (x, y, z, dist float64) {
+	centuries := julianCenturiesTT(d)
+	ex, ey, ez := earthHeliocentricRectangular(centuries / 10)
+
+	var tauSeconds float64
+	for i := 0; i < 5; i++ {
+		dTau := d.AddSeconds(-tauSeconds)
+		centuriesTau := julianCenturiesTT(dTau)
+		px, py, pz := heliocentricRectangular(planet, centuriesTau)
+		x, y, z = px-ex, py-ey, pz-ez
+		dist = math.Sqrt(x*x + y*y + z*z)
+
+		newTauSeconds := lightTimeAUPerDay * dist * daySec
+		delta := newTauSeconds - tauSeconds
+		tauSeconds = newTauSeconds
+		if math.Abs(delta) < lightTimeConvergenceSeconds {
+			break
+		}
+	}
+	return
+}
+
+// GetPlanetPositionApprox calculates a planet's topocentric-free
+// (geocentric) azimuth, altitude (radians) and distance (AU) for the
+// given planet, date and latitude/longitude, from a simplified Keplerian
+// orbital model corrected for light-time. The Approx suffix flags that
+// this is a ~1' two-body approximation rather than a VSOP87 series (see
+// planetElements); it is not a placeholder pending a more precise model.
+func GetPlanetPositionApprox(planet Planet, date time.Time, lat, lng float64) (azim, alti, dist float64) {
+	lw := rad * -lng
+	phi := rad * lat
+	d := toDays(date).AddSeconds(deltaTSeconds(date))
+
+	x, y, z, distance := planetGeocentric(planet, d)
+	lon := math.Atan2(y, x)
+	r := math.Sqrt(x*x + y*y)
+	lat2 := math.Atan2(z, r)
+
+	// lon/lat2 are J2000 mean-ecliptic coordinates (the frame of
+	// planetElements and vsop.EarthHeliocentric), but epsilon here is the
+	// obliquity of date rather than of J2000 - a frame mismatch that is a
+	// no-op at J2000 itself and grows with |date-J2000|. Folded into the
+	// model's existing ~1' accuracy budget rather than correcting it with a
+	// separate of-date/J2000 precession step.
+	centuries := julianCenturiesTT(d)
+	epsilon := MeanObliquity(centuries)
+	dec := declinationEps(lon, lat2, epsilon)
+	ra := rightAscensionEps(lon, lat2, epsilon)
+
+	h := siderealTime(toDays(date), lw) - ra
+	azim = azimuth(h, phi, dec)
+	alti = altitude(h, phi, dec)
+	dist = distance
+	return
+}
+
+// planetAltitude returns a planet's altitude above the horizon, in radians,
+// at time t.
+func planetAltitude(planet Planet, t time.Time, lat, lng float64) float64 {
+	_, alti, _ := GetPlanetPositionApprox(planet, t, lat, lng)
+	return alti
+}
+
+// planetHorizonAltitude is h0, the standard refraction-only altitude at
+// which a planet (a point source, unlike the Moon) is considered to rise or
+// set.
+const planetHorizonAltitude = -0.5667 * rad
+
+// refinePlanetCrossing Newton-refines t, an approximate time at which
+// planetAltitude crosses planetHorizonAltitude, to sub-second precision.
+func refinePlanetCrossing(planet Planet, t time.Time, lat, lng float64) time.Time {
+	for i := 0; i < 20; i++ {
+		fPlus := planetAltitude(planet, t.Add(newtonStep), lat, lng) - planetHorizonAltitude
+		fMinus := planetAltitude(planet, t.Add(-newtonStep), lat, lng) - planetHorizonAltitude
+		deriv := (fPlus - fMinus) / (2 * newtonStep.Seconds())
+		if deriv == 0 {
+			break
+		}
+		f := planetAltitude(planet, t, lat, lng) - planetHorizonAltitude
+		dtSeconds := -f / deriv
+		t = t.Add(time.Duration(dtSeconds * float64(time.Second)))
+		if math.Abs(dtSeconds) < newtonConvergeSeconds {
+			break
+		}
+	}
+	return t
+}
+
+// refinePlanetTransit Newton-refines t, an approximate time of a local
+// maximum of planetAltitude, to sub-second precision.
+func refinePlanetTransit(planet Planet, t time.Time, lat, lng float64) time.Time {
+	for i := 0; i < 20; i++ {
+		fPlus := planetAltitude(planet, t.Add(newtonStep), lat, lng)
+		fMinus := planetAltitude(planet, t.Add(-newtonStep), lat, lng)
+		f0 := planetAltitude(planet, t, lat, lng)
+		slope := (fPlus - fMinus) / (2 * newtonStep.Seconds())
+		curvature := (fPlus - 2*f0 + fMinus) / (newtonStep.Seconds() * newtonStep.Seconds())
+		if curvature == 0 {
+			break
+		}
+		dtSeconds := -slope / curvature
+		t = t.Add(time.Duration(dtSeconds * float64(time.Second)))
+		if math.Abs(dtSeconds) < newtonConvergeSeconds {
+			break
+		}
+	}
+	return t
+}
+
+// GetPlanetTimesApprox calculates a planet's rise, set and transit times
+// for the 24-hour window starting at date, at the given latitude/longitude,
+// reusing GetMoonTimes' hourly-bracket-then-Newton-refine approach with the
+// standard refraction threshold h0 = -0.5667° instead of the Moon's
+// parallax-adjusted one, on top of GetPlanetPositionApprox's ~1' Keplerian
+// model. Returns "planetAlwaysUp"/"planetAlwaysDown" sentinels when the
+// planet never crosses the horizon in the window.
+func GetPlanetTimesApprox(planet Planet, date time.Time, lat, lng float64) map[string]time.Time {
+	result := make(map[string]time.Time)
+
+	const hours = 24
+	altitudes := make([]float64, hours+1)
+	times := make([]time.Time, hours+1)
+	for i := 0; i <= hours; i++ {
+		t := date.Add(time.Duration(i) * time.Hour)
+		times[i] = t
+		altitudes[i] = planetAltitude(planet, t, lat, lng)
+	}
+
+	foundRiseOrSet := false
+	for i := 0; i < hours; i++ {
+		s0 := altitudes[i] - planetHorizonAltitude
+		s1 := altitudes[i+1] - planetHorizonAltitude
+		if s0 < 0 && s1 >= 0 {
+			foundRiseOrSet = true
+			result["rise"] = refinePlanetCrossing(planet, times[i], lat, lng)
+		} else if s0 >= 0 && s1 < 0 {
+			foundRiseOrSet = true
+			result["set"] = refinePlanetCrossing(planet, times[i], lat, lng)
+		}
+	}
+
+	// A second transit ("transit2") needs two interior local maxima in the
+	// 24-hour window, which in turn needs the planet's diurnal period to
+	// fall noticeably short of 24h. Since that period is close to sidereal
+	// (~23h56m) for all seven Planet values, the gap between successive
+	// transits is only a few minutes short of a full day, so the second
+	// maximum almost always lands in the excluded i==0/i==hours edge rather
+	// than the interior range this loop checks - same shape as GetMoonTimes'
+	// lunarTransit2, but far rarer in practice for planets.
+	transitsFound := 0
+	for i := 1; i < hours; i++ {
+		if altitudes[i] > altitudes[i-1] && altitudes[i] > altitudes[i+1] {
+			transit := refinePlanetTransit(planet, times[i], lat, lng)
+			transitsFound++
+			if transitsFound == 1 {
+				result["transit"] = transit
+			} else {
+				result["transit2"] = transit
+			}
+		}
+	}
+
+	if !foundRiseOrSet {
+		allAbove, allBelow := true, true
+		for _, alt := range altitudes {
+			if alt-planetHorizonAltitude < 0 {
+				allAbove = false
+			}
+			if alt-planetHorizonAltitude >= 0 {
+				allBelow = false
+			}
+		}
+		if allAbove {
+			result["planetAlwaysUp"] = date
+		} else if allBelow {
+			result["planetAlwaysDown"] = date
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,94 @@
+package astrocalc
+
+import "math"
+
+// ProperMotion is a star's proper motion in right ascension and declination,
+// in arcseconds per Julian year, applied linearly before precessing.
+type ProperMotion struct {
+	RA, Dec float64
+}
+
+// vec3 is a 3-component Cartesian vector, used internally to carry
+// equatorial coordinates through the precession rotation.
+type vec3 struct{ x, y, z float64 }
+
+// rotateZ and rotateY apply the standard right-handed rotation matrices
+// Rz(angle) and Ry(angle) (Meeus, Astronomical Algorithms, eq. 21.3) to v.
+func rotateZ(v vec3, angle float64) vec3 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return vec3{
+		x: c*v.x + s*v.y,
+		y: -s*v.x + c*v.y,
+		z: v.z,
+	}
+}
+
+func rotateY(v vec3, angle float64) vec3 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return vec3{
+		x: c*v.x - s*v.z,
+		y: v.y,
+		z: s*v.x + c*v.z,
+	}
+}
+
+// precessionAngles returns the three precession angles ζ (zeta), z and θ
+// (theta), in radians, for precessing from epochFromJD to epochToJD (Meeus,
+// Astronomical Algorithms, eq. 21.2).
+func precessionAngles(epochFromJD, epochToJD float64) (zeta, z, theta float64) {
+	bigT := (epochFromJD - j2000) / 36525
+	t := (epochToJD - epochFromJD) / 36525
+
+	linear := (2306.2181 + 1.39656*bigT - 0.000139*bigT*bigT) * t
+
+	zeta = arcsec * (linear + (0.30188-0.000344*bigT)*t*t + 0.017998*t*t*t)
+	z = arcsec * (linear + (1.09468+0.000066*bigT)*t*t + 0.018203*t*t*t)
+	theta = arcsec * ((2004.3109-0.85330*bigT-0.000217*bigT*bigT)*t -
+		(0.42665+0.000217*bigT)*t*t - 0.041833*t*t*t)
+	return
+}
+
+// PrecessEquatorial precesses the equatorial coordinates (raFrom, decFrom),
+// given at Julian Date epochFromJD, to their values at Julian Date
+// epochToJD, using the rigorous IAU 1976 precession formulas (Meeus,
+// Astronomical Algorithms, ch. 21): rotate the unit position vector by
+// Rz(-z)·Ry(θ)·Rz(-ζ). An optional ProperMotion is applied linearly (in
+// arcseconds per Julian year) before the rotation.
+func PrecessEquatorial(raFrom, decFrom, epochFromJD, epochToJD float64, properMotion ...ProperMotion) (raTo, decTo float64) {
+	ra, dec := raFrom, decFrom
+	if len(properMotion) > 0 {
+		years := (epochToJD - epochFromJD) / 365.25
+		ra += properMotion[0].RA * arcsec * years
+		dec += properMotion[0].Dec * arcsec * years
+	}
+
+	v0 := vec3{
+		x: math.Cos(dec) * math.Cos(ra),
+		y: math.Cos(dec) * math.Sin(ra),
+		z: math.Sin(dec),
+	}
+
+	zeta, z, theta := precessionAngles(epochFromJD, epochToJD)
+	v1 := rotateZ(v0, -zeta)
+	v2 := rotateY(v1, theta)
+	v3 := rotateZ(v2, -z)
+
+	raTo = math.Atan2(v3.y, v3.x)
+	if raTo < 0 {
+		raTo += 2 * math.Pi
+	}
+	decTo = math.Asin(v3.z)
+	return
+}
+
+// ToJ2000 precesses (ra, dec), given at Julian Date epochJD, to the J2000.0
+// epoch.
+func ToJ2000(ra, dec, epochJD float64, properMotion ...ProperMotion) (raJ2000, decJ2000 float64) {
+	return PrecessEquatorial(ra, dec, epochJD, j2000, properMotion...)
+}
+
+// FromJ2000 precesses (ra, dec), given at the J2000.0 epoch, to the Julian
+// Date epochJD.
+func FromJ2000(ra, dec, epochJD float64, properMotion ...ProperMotion) (raOut, decOut float64) {
+	return PrecessEquatorial(ra, dec, j2000, epochJD, properMotion...)
+}
@@ -0,0 +1,108 @@
+package astrocalc
+
+import (
+	"math"
+	"time"
+)
+
+// An Observer represents a location on the Earth's surface from which
+// positions are measured: geodetic latitude and longitude in degrees, and
+// elevation above the WGS-84 ellipsoid in meters.
+type Observer struct {
+	Lat       float64
+	Lng       float64
+	Elevation float64
+}
+
+// wgs84EquatorialRadius is the WGS-84 semi-major axis, in meters.
+const wgs84EquatorialRadius = 6378137.0
+
+// wgs84Flattening is the WGS-84 ellipsoid flattening.
+const wgs84Flattening = 1 / 298.257223563
+
+// auKm is one astronomical unit, in kilometers.
+const auKm = 149597870.7
+
+// solarParallaxAtOneAU is the equatorial horizontal parallax of a body at a
+// distance of 1 AU (Meeus, Astronomical Algorithms, eq. 40.6).
+const solarParallaxAtOneAU = 8.794 / 3600 * rad
+
+// geocentricObserverVector returns ρ·sinφ' and ρ·cosφ' for an observer at
+// the given geodetic latitude (radians) and elevation (meters), using the
+// WGS-84 ellipsoid (Meeus, Astronomical Algorithms, eq. 11.1-11.4).
+func geocentricObserverVector(phi, elevation float64) (rhoSinPhiPrime, rhoCosPhiPrime float64) {
+	u := math.Atan((1 - wgs84Flattening) * math.Tan(phi))
+	rhoSinPhiPrime = (1-wgs84Flattening)*math.Sin(u) + (elevation/wgs84EquatorialRadius)*math.Sin(phi)
+	rhoCosPhiPrime = math.Cos(u) + (elevation/wgs84EquatorialRadius)*math.Cos(phi)
+	return
+}
+
+// topocentricEquatorial applies the standard equatorial-parallax correction
+// (Meeus, Astronomical Algorithms, eq. 40.2-40.3) to a geocentric (ra, dec)
+// given the local hour angle H, the distance to the body in kilometers, and
+// the observer's geodetic latitude/elevation. It returns the topocentric
+// (ra, dec) and hour angle.
+func topocentricEquatorial(ra, dec, h, distKm float64, observer Observer) (raPrime, decPrime, hPrime float64) {
+	phi := rad * observer.Lat
+	rhoSinPhiPrime, rhoCosPhiPrime := geocentricObserverVector(phi, observer.Elevation)
+
+	sinPi := math.Sin(solarParallaxAtOneAU) / (distKm / auKm)
+
+	deltaAlpha := math.Atan2(
+		-rhoCosPhiPrime*sinPi*math.Sin(h),
+		math.Cos(dec)-rhoCosPhiPrime*sinPi*math.Cos(h),
+	)
+	decPrime = math.Atan2(
+		(math.Sin(dec)-rhoSinPhiPrime*sinPi)*math.Cos(deltaAlpha),
+		math.Cos(dec)-rhoCosPhiPrime*sinPi*math.Cos(h),
+	)
+	raPrime = ra + deltaAlpha
+	hPrime = h - deltaAlpha
+	return
+}
+
+// GetMoonPositionTopocentric calculates the Moon's topocentric position for
+// an observer at the given date, correcting for the ~1° lunar parallax that
+// GetMoonPosition (a geocentric calculation) ignores. It returns the
+// corrected azimuth/altitude/distance plus the corrected (ra, dec), in case
+// callers need to do their own math with them.
+func GetMoonPositionTopocentric(date time.Time, observer Observer, deltaUT1 ...float64) (azim, alti, dist, ra, dec float64) {
+	lw := rad * -observer.Lng
+	phi := rad * observer.Lat
+	d := toDays(date)
+
+	dec0, ra0, distance := moonCoords(d.AddSeconds(deltaTSeconds(date)))
+	h := siderealTime(d.AddSeconds(deltaUT1Of(deltaUT1)), lw) - ra0
+
+	raPrime, decPrime, hPrime := topocentricEquatorial(ra0, dec0, h, distance, observer)
+
+	alti = altitude(hPrime, phi, decPrime)
+	alti = alti + rad*0.017/math.Tan(alti+rad*10.26/(alti+rad*5.10))
+
+	azim = azimuth(hPrime, phi, decPrime)
+	dist = distance
+	ra = raPrime
+	dec = decPrime
+	return
+}
+
+// GetPositionTopocentric calculates the Sun's topocentric position for an
+// observer at the given date. The Sun's parallax is only a few arcseconds,
+// but this is provided for callers who need the corrected (ra, dec) or want
+// consistent topocentric azimuth/altitude for both bodies.
+func (s *SunCalc) GetPositionTopocentric(date time.Time, observer Observer, deltaUT1 ...float64) (azim, alti, ra, dec float64) {
+	lw := rad * -observer.Lng
+	phi := rad * observer.Lat
+	d := toDays(date)
+
+	dec0, ra0 := sunCoords(d.AddSeconds(deltaTSeconds(date)))
+	h := siderealTime(d.AddSeconds(deltaUT1Of(deltaUT1)), lw) - ra0
+
+	raPrime, decPrime, hPrime := topocentricEquatorial(ra0, dec0, h, sdist, observer)
+
+	azim = azimuth(hPrime, phi, decPrime)
+	alti = altitude(hPrime, phi, decPrime)
+	ra = raPrime
+	dec = decPrime
+	return
+}